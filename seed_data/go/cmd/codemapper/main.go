@@ -0,0 +1,93 @@
+// Command codemapper is a small CLI front end over the seed.Service
+// subsystems: mapping a module, serving its map over HTTP, and inferring a
+// Go struct from sample XML/JSON documents.
+package main
+
+import (
+    "fmt"
+    "io"
+    "os"
+
+    "github.com/Zakskywalker/Code-Mapper/seed"
+)
+
+func main() {
+    if len(os.Args) < 2 {
+        usage()
+        os.Exit(2)
+    }
+
+    switch os.Args[1] {
+    case "map":
+        runMap(os.Args[2:])
+    case "serve":
+        runServe(os.Args[2:])
+    case "genstruct":
+        runGenStruct(os.Args[2:])
+    default:
+        usage()
+        os.Exit(2)
+    }
+}
+
+func usage() {
+    fmt.Fprintln(os.Stderr, `usage:
+  codemapper map <module-root>
+  codemapper serve <module-root> <addr>
+  codemapper genstruct <xml|json> <sample-file> [more-sample-files...]`)
+}
+
+func runMap(args []string) {
+    if len(args) != 1 {
+        usage()
+        os.Exit(2)
+    }
+    svc := seed.NewService("codemapper", args[0])
+    fmt.Println(svc.Hello())
+}
+
+func runServe(args []string) {
+    if len(args) != 2 {
+        usage()
+        os.Exit(2)
+    }
+    svc := seed.NewService("codemapper", args[0])
+    if err := svc.Serve(args[1]); err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+}
+
+func runGenStruct(args []string) {
+    if len(args) < 2 {
+        usage()
+        os.Exit(2)
+    }
+
+    format, paths := args[0], args[1:]
+    files := make([]*os.File, 0, len(paths))
+    defer func() {
+        for _, f := range files {
+            f.Close()
+        }
+    }()
+
+    readers := make([]io.Reader, 0, len(paths))
+    for _, p := range paths {
+        f, err := os.Open(p)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, err)
+            os.Exit(1)
+        }
+        files = append(files, f)
+        readers = append(readers, f)
+    }
+
+    svc := seed.NewService("codemapper")
+    src, err := svc.GenerateStruct(format, readers...)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        os.Exit(1)
+    }
+    fmt.Print(src)
+}
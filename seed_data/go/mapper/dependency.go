@@ -0,0 +1,112 @@
+package mapper
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+
+    "github.com/Zakskywalker/Code-Mapper/resolve"
+)
+
+// LoadDependency resolves importPath to its underlying VCS repository via
+// the resolve package, fetches it into a local cache directory (skipping
+// the fetch if it's already there) and parses it with the same AST
+// pipeline Load uses for the local module. The resulting package is also
+// appended to m.Packages so later Implements queries can see across the
+// boundary.
+func (m *Module) LoadDependency(importPath string) (*Package, error) {
+    entry, err := resolve.Resolve(importPath)
+    if err != nil {
+        return nil, fmt.Errorf("mapper: resolve %s: %w", importPath, err)
+    }
+
+    dir, err := dependencyCacheDir(entry)
+    if err != nil {
+        return nil, fmt.Errorf("mapper: cache dir for %s: %w", importPath, err)
+    }
+
+    if _, err := os.Stat(dir); os.IsNotExist(err) {
+        if err := fetchDependency(entry, dir); err != nil {
+            return nil, fmt.Errorf("mapper: fetch %s: %w", entry.Repo, err)
+        }
+    } else if err != nil {
+        return nil, err
+    }
+
+    // entry.Path is the repo's import-path root (what go-import / the
+    // registry entry covers), which may be a prefix of importPath when
+    // importPath names a subpackage of the repo.
+    sub, err := Load(dir, entry.Path)
+    if err != nil {
+        return nil, err
+    }
+    if len(sub.Packages) == 0 {
+        return nil, fmt.Errorf("mapper: %s: no Go packages found after fetch", importPath)
+    }
+
+    pkg := sub.Packages[0]
+    for _, p := range sub.Packages {
+        if p.ImportPath == importPath {
+            pkg = p
+            break
+        }
+    }
+
+    m.mergeDependencyPackages(sub.Packages)
+    resolveCrossPackageEmbeds(m.Packages)
+
+    return pkg, nil
+}
+
+// mergeDependencyPackages adds pkgs to m.Packages, replacing any existing
+// package with the same ImportPath rather than accumulating duplicates
+// across repeated LoadDependency calls for the same dependency.
+func (m *Module) mergeDependencyPackages(pkgs []*Package) {
+    byPath := make(map[string]int, len(m.Packages))
+    for i, p := range m.Packages {
+        byPath[p.ImportPath] = i
+    }
+    for _, p := range pkgs {
+        if i, ok := byPath[p.ImportPath]; ok {
+            m.Packages[i] = p
+            continue
+        }
+        m.Packages = append(m.Packages, p)
+    }
+}
+
+func dependencyCacheDir(entry resolve.Entry) (string, error) {
+    base, err := os.UserCacheDir()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(base, "code-mapper", "dep", sanitizeForPath(entry.Repo)), nil
+}
+
+func sanitizeForPath(s string) string {
+    r := strings.NewReplacer("://", "_", "/", "_", ":", "_")
+    return r.Replace(s)
+}
+
+func fetchDependency(entry resolve.Entry, dir string) error {
+    if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+        return err
+    }
+    switch entry.VCS {
+    case resolve.Git:
+        return runCmd("git", "clone", "--depth", "1", entry.Repo, dir)
+    case resolve.Hg:
+        return runCmd("hg", "clone", entry.Repo, dir)
+    default:
+        return fmt.Errorf("mapper: unsupported vcs %q for %s", entry.VCS, entry.Repo)
+    }
+}
+
+func runCmd(name string, args ...string) error {
+    cmd := exec.Command(name, args...)
+    cmd.Stdout = os.Stdout
+    cmd.Stderr = os.Stderr
+    return cmd.Run()
+}
@@ -0,0 +1,302 @@
+// Package mapper walks a Go module's source tree and builds a structured
+// map of its packages, exported types, functions and interfaces.
+package mapper
+
+import (
+    "fmt"
+    "go/ast"
+    "go/parser"
+    "go/token"
+    "os"
+    "path/filepath"
+    "strings"
+)
+
+// Module is the top-level result of mapping a Go module's source tree.
+type Module struct {
+    Root     string
+    Packages []*Package
+}
+
+// Package describes a single Go package discovered while walking the module.
+type Package struct {
+    Name       string
+    ImportPath string
+    Dir        string
+
+    Types      []*Type
+    Funcs      []*Func
+    Interfaces []*Interface
+}
+
+// Type describes an exported struct or named type and the methods declared
+// on it (across all files in its package).
+type Type struct {
+    Name    string
+    Doc     string
+    Methods []*Method
+}
+
+// Interface describes an exported interface, with its method set fully
+// resolved across embedded interfaces (including embeds that live in other
+// files of the same package).
+type Interface struct {
+    Name    string
+    Doc     string
+    Methods []*Method
+}
+
+// Method is a single method signature belonging to a Type or Interface.
+type Method struct {
+    Name string
+    Sig  string
+}
+
+// Func describes a package-level exported function.
+type Func struct {
+    Name string
+    Sig  string
+}
+
+// Load walks rootDir, parses every .go file it finds (skipping tests,
+// vendor and hidden directories) and returns the resulting Module.
+// modulePath is the import path that corresponds to rootDir and is used to
+// derive the ImportPath of every discovered Package.
+func Load(rootDir, modulePath string) (*Module, error) {
+    mod := &Module{Root: rootDir}
+
+    byDir := map[string][]*ast.File{}
+    fset := token.NewFileSet()
+
+    err := filepath.WalkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if d.IsDir() {
+            name := d.Name()
+            if path != rootDir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+                return filepath.SkipDir
+            }
+            return nil
+        }
+        if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+            return nil
+        }
+        f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+        if err != nil {
+            return fmt.Errorf("mapper: parse %s: %w", path, err)
+        }
+        dir := filepath.Dir(path)
+        byDir[dir] = append(byDir[dir], f)
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    for dir, files := range byDir {
+        pkg, err := buildPackage(rootDir, modulePath, dir, files)
+        if err != nil {
+            return nil, err
+        }
+        if pkg != nil {
+            mod.Packages = append(mod.Packages, pkg)
+        }
+    }
+
+    resolveCrossPackageEmbeds(mod.Packages)
+
+    return mod, nil
+}
+
+func buildPackage(rootDir, modulePath, dir string, files []*ast.File) (*Package, error) {
+    if len(files) == 0 {
+        return nil, nil
+    }
+
+    rel, err := filepath.Rel(rootDir, dir)
+    if err != nil {
+        return nil, err
+    }
+    importPath := modulePath
+    if rel != "." {
+        importPath = modulePath + "/" + filepath.ToSlash(rel)
+    }
+
+    pkg := &Package{
+        Name:       files[0].Name.Name,
+        ImportPath: importPath,
+        Dir:        dir,
+    }
+
+    methodsByRecv := map[string][]*Method{}
+
+    // Index every named type declared anywhere in the package before
+    // flattening interfaces, so an embedded identifier can be resolved
+    // regardless of which file declares it (go/parser only resolves
+    // *ast.Ident.Obj within a single file).
+    typeSpecs := map[string]*ast.TypeSpec{}
+    for _, f := range files {
+        for _, decl := range f.Decls {
+            d, ok := decl.(*ast.GenDecl)
+            if !ok || d.Tok != token.TYPE {
+                continue
+            }
+            for _, spec := range d.Specs {
+                if ts, ok := spec.(*ast.TypeSpec); ok {
+                    typeSpecs[ts.Name.Name] = ts
+                }
+            }
+        }
+    }
+
+    for _, f := range files {
+        for _, decl := range f.Decls {
+            switch d := decl.(type) {
+            case *ast.FuncDecl:
+                if d.Recv == nil {
+                    if d.Name.IsExported() {
+                        pkg.Funcs = append(pkg.Funcs, &Func{
+                            Name: d.Name.Name,
+                            Sig:  fieldListString(d.Type.Params) + " " + fieldListString(d.Type.Results),
+                        })
+                    }
+                    continue
+                }
+                recv := receiverTypeName(d.Recv)
+                if recv == "" || !d.Name.IsExported() {
+                    continue
+                }
+                methodsByRecv[recv] = append(methodsByRecv[recv], &Method{
+                    Name: d.Name.Name,
+                    Sig:  fieldListString(d.Type.Params) + " " + fieldListString(d.Type.Results),
+                })
+            case *ast.GenDecl:
+                if d.Tok != token.TYPE {
+                    continue
+                }
+                for _, spec := range d.Specs {
+                    ts, ok := spec.(*ast.TypeSpec)
+                    if !ok || !ts.Name.IsExported() {
+                        continue
+                    }
+                    doc := docText(d.Doc)
+                    if iface, ok := ts.Type.(*ast.InterfaceType); ok {
+                        methods := collectInterfaceMethods(iface, typeSpecs, map[string]bool{ts.Name.Name: true})
+                        pkg.Interfaces = append(pkg.Interfaces, &Interface{
+                            Name:    ts.Name.Name,
+                            Doc:     doc,
+                            Methods: methods,
+                        })
+                        continue
+                    }
+                    pkg.Types = append(pkg.Types, &Type{Name: ts.Name.Name, Doc: doc})
+                }
+            }
+        }
+    }
+
+    for _, t := range pkg.Types {
+        t.Methods = methodsByRecv[t.Name]
+    }
+
+    return pkg, nil
+}
+
+// Implements reports whether typ (an exported type name within the module)
+// satisfies the method set of iface (an exported interface name within the
+// module).
+func (m *Module) Implements(iface, typ string) bool {
+    ifc := m.findInterface(iface)
+    t := m.findType(typ)
+    if ifc == nil || t == nil {
+        return false
+    }
+    have := map[string]bool{}
+    for _, meth := range t.Methods {
+        have[meth.Name+meth.Sig] = true
+    }
+    for _, meth := range ifc.Methods {
+        if !have[meth.Name+meth.Sig] {
+            return false
+        }
+    }
+    return true
+}
+
+func (m *Module) findInterface(name string) *Interface {
+    for _, pkg := range m.Packages {
+        for _, i := range pkg.Interfaces {
+            if i.Name == name {
+                return i
+            }
+        }
+    }
+    return nil
+}
+
+func (m *Module) findType(name string) *Type {
+    for _, pkg := range m.Packages {
+        for _, t := range pkg.Types {
+            if t.Name == name {
+                return t
+            }
+        }
+    }
+    return nil
+}
+
+func receiverTypeName(fl *ast.FieldList) string {
+    if fl == nil || len(fl.List) == 0 {
+        return ""
+    }
+    expr := fl.List[0].Type
+    if star, ok := expr.(*ast.StarExpr); ok {
+        expr = star.X
+    }
+    if ident, ok := expr.(*ast.Ident); ok {
+        return ident.Name
+    }
+    return ""
+}
+
+func docText(cg *ast.CommentGroup) string {
+    if cg == nil {
+        return ""
+    }
+    return strings.TrimSpace(cg.Text())
+}
+
+func fieldListString(fl *ast.FieldList) string {
+    if fl == nil {
+        return "()"
+    }
+    var parts []string
+    for _, f := range fl.List {
+        n := len(f.Names)
+        if n == 0 {
+            n = 1
+        }
+        for i := 0; i < n; i++ {
+            parts = append(parts, exprString(f.Type))
+        }
+    }
+    return "(" + strings.Join(parts, ", ") + ")"
+}
+
+func exprString(e ast.Expr) string {
+    switch t := e.(type) {
+    case *ast.Ident:
+        return t.Name
+    case *ast.StarExpr:
+        return "*" + exprString(t.X)
+    case *ast.SelectorExpr:
+        return exprString(t.X) + "." + t.Sel.Name
+    case *ast.ArrayType:
+        return "[]" + exprString(t.Elt)
+    case *ast.Ellipsis:
+        return "..." + exprString(t.Elt)
+    default:
+        return "?"
+    }
+}
@@ -0,0 +1,127 @@
+package mapper
+
+import "go/ast"
+
+// collectInterfaceMethods recursively collects the method set of iface,
+// following embedded interfaces anywhere in the same package (typeSpecs
+// indexes every named type declared in any file of the package, so this
+// works whether the embed lives in the same file or a sibling one — unlike
+// *ast.Ident.Obj, which go/parser only resolves within a single file). seen
+// guards against embedding cycles. This mirrors the approach Hugo's
+// codegen/methods.go uses to flatten embedded interfaces: walk the
+// *ast.InterfaceType's field list, and for every embedded field that is an
+// *ast.Ident, resolve it by name and recurse.
+func collectInterfaceMethods(iface *ast.InterfaceType, typeSpecs map[string]*ast.TypeSpec, seen map[string]bool) []*Method {
+    var methods []*Method
+
+    if iface.Methods == nil {
+        return methods
+    }
+
+    for _, field := range iface.Methods.List {
+        if len(field.Names) > 0 {
+            // A named method.
+            ft, ok := field.Type.(*ast.FuncType)
+            if !ok {
+                continue
+            }
+            methods = append(methods, &Method{
+                Name: field.Names[0].Name,
+                Sig:  fieldListString(ft.Params) + " " + fieldListString(ft.Results),
+            })
+            continue
+        }
+
+        // An embedded interface, e.g. `io.Reader` or `Reader`.
+        switch embed := field.Type.(type) {
+        case *ast.Ident:
+            if seen[embed.Name] {
+                continue
+            }
+            seen[embed.Name] = true
+
+            ts, ok := typeSpecs[embed.Name]
+            if !ok {
+                // Not a type declared in this package (e.g. a dot import
+                // or builtin); nothing we can flatten.
+                continue
+            }
+
+            // Edge case: the embedded identifier might resolve to a type
+            // alias or a struct rather than an interface (e.g. `type Foo
+            // = SomeStruct`). Use a type switch instead of an unchecked
+            // assertion so we skip it cleanly.
+            switch embedded := ts.Type.(type) {
+            case *ast.InterfaceType:
+                methods = append(methods, collectInterfaceMethods(embedded, typeSpecs, seen)...)
+            default:
+                // Not an interface; nothing to flatten.
+            }
+        case *ast.SelectorExpr:
+            // Cross-package embed such as `otherpkg.Reader`. We don't have
+            // the other package's AST in scope yet, so defer resolution to
+            // the second pass.
+            pkgIdent, ok := embed.X.(*ast.Ident)
+            if !ok {
+                continue
+            }
+            methods = appendPendingMarker(methods, pkgIdent.Name, embed.Sel.Name)
+        }
+    }
+
+    return methods
+}
+
+// pendingMethodSig marks a sentinel Method that threads a cross-package
+// embed (e.g. `otherpkg.Reader`) through collectInterfaceMethods' return
+// value; resolveCrossPackageEmbeds replaces these with the real methods
+// once every package in the module has been built.
+const pendingMethodSig = "<cross-package-embed>"
+
+func appendPendingMarker(methods []*Method, pkgAlias, ifaceName string) []*Method {
+    return append(methods, &Method{Name: pkgAlias + "." + ifaceName, Sig: pendingMethodSig})
+}
+
+// resolveCrossPackageEmbeds performs the second resolution pass: any
+// interface that recorded a cross-package embed (marked via
+// appendPendingMarker) gets the embedded package's interface methods
+// spliced in, looked up by matching Package.Name against the embed's
+// package alias.
+func resolveCrossPackageEmbeds(pkgs []*Package) {
+    byName := map[string]*Package{}
+    for _, pkg := range pkgs {
+        byName[pkg.Name] = pkg
+    }
+
+    for _, pkg := range pkgs {
+        for _, iface := range pkg.Interfaces {
+            var resolved []*Method
+            for _, m := range iface.Methods {
+                if m.Sig != pendingMethodSig {
+                    resolved = append(resolved, m)
+                    continue
+                }
+                alias, name := splitPendingName(m.Name)
+                other, ok := byName[alias]
+                if !ok {
+                    continue
+                }
+                for _, oi := range other.Interfaces {
+                    if oi.Name == name {
+                        resolved = append(resolved, oi.Methods...)
+                    }
+                }
+            }
+            iface.Methods = resolved
+        }
+    }
+}
+
+func splitPendingName(s string) (alias, name string) {
+    for i := len(s) - 1; i >= 0; i-- {
+        if s[i] == '.' {
+            return s[:i], s[i+1:]
+        }
+    }
+    return "", s
+}
@@ -0,0 +1,143 @@
+// Package resolve discovers the VCS and repository URL behind a Go import
+// path, either from a local registry of known paths or, failing that, by
+// fetching the `go-import` HTML meta tag the same way the `go` tool itself
+// does ("go help importpath").
+package resolve
+
+import (
+    "fmt"
+    "io"
+    "net/http"
+    "regexp"
+    "strings"
+    "time"
+)
+
+// httpClient bounds how long a go-import meta tag lookup can block; the
+// hosts behind arbitrary import paths aren't trusted to respond promptly.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// VCS identifies the version control system a dependency is hosted under.
+type VCS int
+
+const (
+    Git VCS = iota
+    Hg
+)
+
+func (v VCS) String() string {
+    switch v {
+    case Git:
+        return "git"
+    case Hg:
+        return "hg"
+    default:
+        return "unknown"
+    }
+}
+
+func parseVCS(s string) (VCS, bool) {
+    switch s {
+    case "git":
+        return Git, true
+    case "hg":
+        return Hg, true
+    default:
+        return 0, false
+    }
+}
+
+// Entry is one {import-path-prefix, VCS, repo-URL} tuple, either registered
+// directly via Register or discovered from a go-import meta tag.
+type Entry struct {
+    Path string
+    VCS  VCS
+    Repo string
+}
+
+var registry []Entry
+
+// Register adds a local entry that Resolve will prefer over fetching the
+// go-import meta tag, keyed by the import path prefix it covers.
+func Register(path string, vcs VCS, repo string) {
+    registry = append(registry, Entry{Path: path, VCS: vcs, Repo: repo})
+}
+
+// Resolve returns the VCS entry that covers importPath: a registered entry
+// if one's path prefixes importPath, otherwise whatever the target host's
+// go-import meta tag advertises.
+func Resolve(importPath string) (Entry, error) {
+    if e, ok := bestMatch(registry, importPath); ok {
+        return e, nil
+    }
+    return discoverMeta(importPath)
+}
+
+// bestMatch picks, among entries whose Path prefixes importPath, the one
+// with the longest Path — the same "longest matching prefix wins" rule
+// Resolve applies when a go-import response offers multiple candidates.
+func bestMatch(entries []Entry, importPath string) (Entry, bool) {
+    var best Entry
+    found := false
+    for _, e := range entries {
+        if !isPathPrefix(importPath, e.Path) {
+            continue
+        }
+        if !found || len(e.Path) > len(best.Path) {
+            best = e
+            found = true
+        }
+    }
+    return best, found
+}
+
+// isPathPrefix reports whether prefix is importPath itself or a leading
+// slash-delimited segment of it, so "example.com/foo" matches
+// "example.com/foo/bar" but not "example.com/foobar".
+func isPathPrefix(importPath, prefix string) bool {
+    if !strings.HasPrefix(importPath, prefix) {
+        return false
+    }
+    return len(importPath) == len(prefix) || importPath[len(prefix)] == '/'
+}
+
+var goImportMetaRe = regexp.MustCompile(`<meta\s+name=["']go-import["']\s+content=["']([^"']+)["']\s*/?>`)
+
+// discoverMeta fetches https://importPath?go-get=1 and parses any
+// go-import meta tags out of the response body.
+func discoverMeta(importPath string) (Entry, error) {
+    url := "https://" + importPath + "?go-get=1"
+    resp, err := httpClient.Get(url)
+    if err != nil {
+        return Entry{}, fmt.Errorf("resolve: fetch %s: %w", url, err)
+    }
+    defer resp.Body.Close()
+
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return Entry{}, fmt.Errorf("resolve: read %s: %w", url, err)
+    }
+
+    candidates := parseGoImportMetas(string(body))
+    e, ok := bestMatch(candidates, importPath)
+    if !ok {
+        return Entry{}, fmt.Errorf("resolve: no go-import meta tag found for %q", importPath)
+    }
+    return e, nil
+}
+
+func parseGoImportMetas(body string) []Entry {
+    var entries []Entry
+    for _, m := range goImportMetaRe.FindAllStringSubmatch(body, -1) {
+        fields := strings.Fields(m[1])
+        if len(fields) != 3 {
+            continue
+        }
+        vcs, ok := parseVCS(fields[1])
+        if !ok {
+            continue
+        }
+        entries = append(entries, Entry{Path: fields[0], VCS: vcs, Repo: fields[2]})
+    }
+    return entries
+}
@@ -0,0 +1,50 @@
+package schemagen
+
+import (
+    "fmt"
+    "strings"
+)
+
+// emitXMLStruct renders n's attributes, chardata and children as struct
+// field lines. Single-occurrence children become anonymous nested structs;
+// children seen more than once anywhere are promoted to named []struct
+// slices. indent is only used for XMLName, which only the root carries.
+func emitXMLStruct(n *node, depth int) string {
+    var b strings.Builder
+
+    if depth == 0 {
+        fmt.Fprintf(&b, "XMLName xml.Name `xml:\"%s\"`\n", n.name)
+    }
+
+    for _, attr := range sortedKeys(n.attrs) {
+        fmt.Fprintf(&b, "%s string `xml:\"%s,attr\"`\n", exportName(attr), attr)
+    }
+
+    for _, name := range n.order {
+        child := n.children[name]
+        if n.multi[name] {
+            fmt.Fprintf(&b, "%s []struct {\n%s} `xml:\"%s\"`\n",
+                exportName(name), indentBlock(emitXMLStruct(child, depth+1)), name)
+            continue
+        }
+        fmt.Fprintf(&b, "%s struct {\n%s} `xml:\"%s\"`\n",
+            exportName(name), indentBlock(emitXMLStruct(child, depth+1)), name)
+    }
+
+    if len(n.samples) > 0 {
+        fmt.Fprintf(&b, "CharData string `xml:\",chardata\"` // %s\n", strings.Join(n.samples, ", "))
+    }
+
+    return b.String()
+}
+
+func indentBlock(s string) string {
+    if s == "" {
+        return ""
+    }
+    lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+    for i, l := range lines {
+        lines[i] = "\t" + l
+    }
+    return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,142 @@
+package schemagen
+
+import (
+    "encoding/json"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// jnode is the JSON counterpart of node: a tree keyed by object field name,
+// built by walking the token stream of one or more sample JSON documents so
+// that field order is preserved the way it appears in the source.
+type jnode struct {
+    order     []string
+    children  map[string]*jnode
+    sawObject bool
+    sawArray  bool
+    sawScalar bool
+    arrayElem *jnode
+    samples   []string
+}
+
+func newJNode() *jnode {
+    return &jnode{children: map[string]*jnode{}}
+}
+
+func (n *jnode) child(name string) *jnode {
+    c, ok := n.children[name]
+    if !ok {
+        c = newJNode()
+        n.children[name] = c
+        n.order = append(n.order, name)
+    }
+    return c
+}
+
+func (n *jnode) addSample(s string) {
+    if len(n.samples) >= 3 {
+        return
+    }
+    n.samples = append(n.samples, truncate(s, maxSampleLen))
+}
+
+// FromJSONReaders ingests one or more sample JSON documents and returns a
+// formatted Go struct declaration (named Root) suitable for
+// encoding/json.Unmarshal.
+func FromJSONReaders(rs ...io.Reader) (string, error) {
+    root := newJNode()
+    for _, r := range rs {
+        if err := ingestJSON(r, root); err != nil {
+            return "", err
+        }
+    }
+    return render("Root", emitJSONStruct(root, 0))
+}
+
+func ingestJSON(r io.Reader, root *jnode) error {
+    dec := json.NewDecoder(r)
+    return parseJSONValue(dec, root)
+}
+
+// parseJSONValue reads the next JSON value from dec and merges it into n.
+func parseJSONValue(dec *json.Decoder, n *jnode) error {
+    tok, err := dec.Token()
+    if err != nil {
+        return err
+    }
+    switch t := tok.(type) {
+    case json.Delim:
+        switch t {
+        case '{':
+            return parseJSONObjectBody(dec, n)
+        case '[':
+            n.sawArray = true
+            if n.arrayElem == nil {
+                n.arrayElem = newJNode()
+            }
+            for dec.More() {
+                if err := parseJSONValue(dec, n.arrayElem); err != nil {
+                    return err
+                }
+            }
+            _, err := dec.Token() // closing ']'
+            return err
+        }
+    case string:
+        n.sawScalar = true
+        n.addSample(t)
+    default:
+        // number, bool or null.
+        n.sawScalar = true
+        n.addSample(fmt.Sprint(t))
+    }
+    return nil
+}
+
+func parseJSONObjectBody(dec *json.Decoder, n *jnode) error {
+    n.sawObject = true
+    for dec.More() {
+        keyTok, err := dec.Token()
+        if err != nil {
+            return err
+        }
+        key, _ := keyTok.(string)
+        if err := parseJSONValue(dec, n.child(key)); err != nil {
+            return err
+        }
+    }
+    _, err := dec.Token() // closing '}'
+    return err
+}
+
+// emitJSONStruct mirrors emitXMLStruct: single-value fields become plain
+// (string-typed, for simplicity) fields, object fields nest anonymously,
+// and arrays are promoted to slices of their element's shape.
+func emitJSONStruct(n *jnode, depth int) string {
+    var b strings.Builder
+
+    for _, name := range n.order {
+        child := n.children[name]
+        field := exportName(name)
+
+        switch {
+        case child.sawArray && child.arrayElem != nil && child.arrayElem.sawObject:
+            fmt.Fprintf(&b, "%s []struct {\n%s} `json:\"%s\"`\n",
+                field, indentBlock(emitJSONStruct(child.arrayElem, depth+1)), name)
+        case child.sawArray:
+            fmt.Fprintf(&b, "%s []string `json:\"%s\"`\n", field, name)
+        case child.sawObject:
+            fmt.Fprintf(&b, "%s struct {\n%s} `json:\"%s\"`\n",
+                field, indentBlock(emitJSONStruct(child, depth+1)), name)
+        default:
+            comment := ""
+            if len(child.samples) > 0 {
+                comment = " // " + strings.Join(child.samples, ", ")
+            }
+            fmt.Fprintf(&b, "%s string `json:\"%s\"`%s\n", field, name, comment)
+        }
+    }
+
+    return b.String()
+}
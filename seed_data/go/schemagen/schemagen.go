@@ -0,0 +1,174 @@
+// Package schemagen infers a Go struct declaration from sample XML or JSON
+// documents, the way zek (https://github.com/miku/zek) infers structs for
+// encoding/xml from example documents: ingest one or more samples into a
+// tree keyed by element name, then walk the tree and emit a struct with
+// fields merged across repeated siblings.
+package schemagen
+
+import (
+    "bytes"
+    "encoding/xml"
+    "go/format"
+    "io"
+    "sort"
+    "strings"
+)
+
+// maxSampleLen bounds the inline example-value comments zek-style output
+// carries next to each field.
+const maxSampleLen = 25
+
+// node is one element in the tree built while ingesting sample documents.
+// It accumulates everything seen across every sample and every occurrence
+// of that element, so that after ingest we know whether a child should be
+// promoted to a slice and what an example value for a leaf looks like.
+type node struct {
+    name     string
+    attrs    map[string]bool
+    order    []string // child element names, in first-seen order
+    children map[string]*node
+    multi    map[string]bool // child name -> seen more than once in some occurrence
+    samples  []string        // observed chardata, truncated
+}
+
+func newNode(name string) *node {
+    return &node{
+        name:     name,
+        attrs:    map[string]bool{},
+        children: map[string]*node{},
+        multi:    map[string]bool{},
+    }
+}
+
+func (n *node) addSample(s string) {
+    if len(n.samples) >= 3 {
+        return
+    }
+    n.samples = append(n.samples, truncate(s, maxSampleLen))
+}
+
+func (n *node) child(name string) *node {
+    c, ok := n.children[name]
+    if !ok {
+        c = newNode(name)
+        n.children[name] = c
+        n.order = append(n.order, name)
+    }
+    return c
+}
+
+// FromReaders ingests one or more sample XML documents and returns a
+// formatted Go struct declaration (named Root) suitable for
+// encoding/xml.Unmarshal.
+func FromReaders(rs ...io.Reader) (string, error) {
+    root := newNode("root")
+    for _, r := range rs {
+        if err := ingestXML(r, root); err != nil {
+            return "", err
+        }
+    }
+    return render("Root", emitXMLStruct(root, 0))
+}
+
+func ingestXML(r io.Reader, root *node) error {
+    dec := xml.NewDecoder(r)
+    for {
+        tok, err := dec.Token()
+        if err == io.EOF {
+            return nil
+        }
+        if err != nil {
+            return err
+        }
+        if start, ok := tok.(xml.StartElement); ok {
+            root.name = start.Name.Local
+            return parseElement(dec, start, root)
+        }
+    }
+}
+
+func parseElement(dec *xml.Decoder, start xml.StartElement, n *node) error {
+    for _, attr := range start.Attr {
+        n.attrs[attr.Name.Local] = true
+    }
+
+    counts := map[string]int{}
+    for {
+        tok, err := dec.Token()
+        if err != nil {
+            return err
+        }
+        switch t := tok.(type) {
+        case xml.StartElement:
+            child := n.child(t.Name.Local)
+            counts[t.Name.Local]++
+            if err := parseElement(dec, t, child); err != nil {
+                return err
+            }
+        case xml.CharData:
+            if text := strings.TrimSpace(string(t)); text != "" {
+                n.addSample(text)
+            }
+        case xml.EndElement:
+            for name, c := range counts {
+                if c > 1 {
+                    n.multi[name] = true
+                }
+            }
+            return nil
+        }
+    }
+}
+
+// render gofmt's body (the field list of the root struct) into a full
+// struct declaration named typeName.
+func render(typeName string, body string) (string, error) {
+    var buf bytes.Buffer
+    buf.WriteString("type " + typeName + " struct {\n")
+    buf.WriteString(body)
+    buf.WriteString("}\n")
+
+    out, err := format.Source(buf.Bytes())
+    if err != nil {
+        // Return the unformatted source rather than nothing; a caller can
+        // still read and fix up a syntax error in the inferred schema.
+        return buf.String(), err
+    }
+    return string(out), nil
+}
+
+func truncate(s string, n int) string {
+    s = strings.Join(strings.Fields(s), " ")
+    r := []rune(s)
+    if len(r) <= n {
+        return s
+    }
+    return string(r[:n]) + "..."
+}
+
+func exportName(s string) string {
+    parts := strings.FieldsFunc(s, func(r rune) bool {
+        return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+    })
+    var b strings.Builder
+    for _, p := range parts {
+        if p == "" {
+            continue
+        }
+        b.WriteString(strings.ToUpper(p[:1]) + p[1:])
+    }
+    name := b.String()
+    if name == "" {
+        return "Field"
+    }
+    return name
+}
+
+func sortedKeys(m map[string]bool) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
@@ -0,0 +1,79 @@
+package seed
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "path/filepath"
+
+    "github.com/Zakskywalker/Code-Mapper/mapper"
+    "github.com/Zakskywalker/Code-Mapper/schemagen"
+    "github.com/Zakskywalker/Code-Mapper/server"
+)
+
+type Service struct {
+    Name       string
+    ModuleRoot string
+}
+
+// NewService builds a Service for name. moduleRoot is optional: when given,
+// Hello also walks the module at that path with the mapper package and
+// reports a short summary of what it found.
+func NewService(name string, moduleRoot ...string) Service {
+    s := Service{Name: name}
+    if len(moduleRoot) > 0 {
+        s.ModuleRoot = moduleRoot[0]
+    }
+    return s
+}
+
+func (s Service) Hello() string {
+    greeting := "hello " + s.Name
+    if s.ModuleRoot == "" {
+        return greeting
+    }
+
+    mod, err := mapper.Load(s.ModuleRoot, filepath.Base(s.ModuleRoot))
+    if err != nil {
+        return fmt.Sprintf("%s (mapper: %v)", greeting, err)
+    }
+
+    var types, funcs, ifaces int
+    for _, pkg := range mod.Packages {
+        types += len(pkg.Types)
+        funcs += len(pkg.Funcs)
+        ifaces += len(pkg.Interfaces)
+    }
+
+    return fmt.Sprintf("%s (mapped %d package(s): %d type(s), %d func(s), %d interface(s))",
+        greeting, len(mod.Packages), types, funcs, ifaces)
+}
+
+// Serve maps s.ModuleRoot and serves the result over HTTP on addr until the
+// server stops or fails.
+func (s Service) Serve(addr string) error {
+    if s.ModuleRoot == "" {
+        return errors.New("seed: Serve requires a ModuleRoot")
+    }
+
+    mod, err := mapper.Load(s.ModuleRoot, filepath.Base(s.ModuleRoot))
+    if err != nil {
+        return fmt.Errorf("seed: Serve: %w", err)
+    }
+
+    return server.New(mod).ListenAndServe(addr)
+}
+
+// GenerateStruct infers a Go struct declaration from one or more sample
+// documents of the given format ("xml" or "json"). It backs both the
+// `genstruct` CLI subcommand and the server's /schema endpoints.
+func (s Service) GenerateStruct(format string, rs ...io.Reader) (string, error) {
+    switch format {
+    case "xml":
+        return schemagen.FromReaders(rs...)
+    case "json":
+        return schemagen.FromJSONReaders(rs...)
+    default:
+        return "", fmt.Errorf("seed: GenerateStruct: unknown format %q", format)
+    }
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+    "encoding/json"
+    "fmt"
+    "html/template"
+    "net/http"
+    "strings"
+)
+
+// render writes v to w, picking the body format from the request's Accept
+// header: HTML (rendered from the given template under name) when the
+// client asks for text/html, plain text when it asks for text/plain, and
+// JSON otherwise.
+func render(w http.ResponseWriter, r *http.Request, tmpl *template.Template, name string, v interface{}) {
+    switch negotiate(r) {
+    case "html":
+        w.Header().Set("Content-Type", "text/html; charset=utf-8")
+        if err := tmpl.ExecuteTemplate(w, name, v); err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+        }
+    case "text":
+        w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+        fmt.Fprintf(w, "%+v\n", v)
+    default:
+        w.Header().Set("Content-Type", "application/json; charset=utf-8")
+        enc := json.NewEncoder(w)
+        enc.SetIndent("", "  ")
+        if err := enc.Encode(v); err != nil {
+            http.Error(w, err.Error(), http.StatusInternalServerError)
+        }
+    }
+}
+
+// negotiate inspects the Accept header and returns "html", "text" or
+// "json" (the default).
+func negotiate(r *http.Request) string {
+    accept := r.Header.Get("Accept")
+    switch {
+    case strings.Contains(accept, "text/html"):
+        return "html"
+    case strings.Contains(accept, "text/plain"):
+        return "text"
+    default:
+        return "json"
+    }
+}
@@ -0,0 +1,146 @@
+// Package server exposes a mapper.Module over HTTP, negotiating the
+// response body between JSON, HTML and plain text based on the request's
+// Accept header — the same small content-negotiation flow used by dip-style
+// webservices, just without pulling in a framework.
+package server
+
+import (
+    "embed"
+    "html/template"
+    "net/http"
+    "strings"
+
+    "github.com/Zakskywalker/Code-Mapper/mapper"
+    "github.com/Zakskywalker/Code-Mapper/schemagen"
+)
+
+//go:embed assets
+var assetsFS embed.FS
+
+//go:embed templates/map.html.tmpl
+var templatesFS embed.FS
+
+// Server serves a mapper.Module over HTTP.
+type Server struct {
+    mod  *mapper.Module
+    mux  *http.ServeMux
+    tmpl *template.Template
+}
+
+// New builds a Server for mod. Routes are registered immediately; callers
+// only need to call ListenAndServe.
+func New(mod *mapper.Module) *Server {
+    s := &Server{
+        mod:  mod,
+        mux:  http.NewServeMux(),
+        tmpl: template.Must(template.ParseFS(templatesFS, "templates/map.html.tmpl")),
+    }
+
+    s.mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.FS(assetsFS))))
+    s.mux.HandleFunc("/map", s.handleMap)
+    s.mux.HandleFunc("/map/pkg/", s.handlePackage)
+    s.mux.HandleFunc("/map/type/", s.handleType)
+    s.mux.HandleFunc("/schema/xml", s.handleSchemaXML)
+    s.mux.HandleFunc("/schema/json", s.handleSchemaJSON)
+
+    return s
+}
+
+// ListenAndServe boots the HTTP server on addr.
+func (s *Server) ListenAndServe(addr string) error {
+    return http.ListenAndServe(addr, s.mux)
+}
+
+func (s *Server) handleMap(w http.ResponseWriter, r *http.Request) {
+    render(w, r, s.tmpl, "module", s.mod)
+}
+
+func (s *Server) handlePackage(w http.ResponseWriter, r *http.Request) {
+    importPath := strings.TrimPrefix(r.URL.Path, "/map/pkg/")
+    for _, pkg := range s.mod.Packages {
+        if pkg.ImportPath == importPath {
+            render(w, r, s.tmpl, "package", pkg)
+            return
+        }
+    }
+    http.NotFound(w, r)
+}
+
+func (s *Server) handleType(w http.ResponseWriter, r *http.Request) {
+    rest := strings.TrimPrefix(r.URL.Path, "/map/type/")
+    i := strings.LastIndex(rest, "/")
+    if i < 0 {
+        http.NotFound(w, r)
+        return
+    }
+    importPath, name := rest[:i], rest[i+1:]
+
+    for _, pkg := range s.mod.Packages {
+        if pkg.ImportPath != importPath {
+            continue
+        }
+        for _, t := range pkg.Types {
+            if t.Name == name {
+                render(w, r, s.tmpl, "type", t)
+                return
+            }
+        }
+        for _, iface := range pkg.Interfaces {
+            if iface.Name == name {
+                render(w, r, s.tmpl, "type", typeView{Interface: iface, Implementers: s.implementers(iface.Name)})
+                return
+            }
+        }
+    }
+    http.NotFound(w, r)
+}
+
+// handleSchemaXML infers a Go struct from the POSTed XML document body and
+// returns the generated source as plain text.
+func (s *Server) handleSchemaXML(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        http.Error(w, "POST only", http.StatusMethodNotAllowed)
+        return
+    }
+    src, err := schemagen.FromReaders(r.Body)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+    w.Write([]byte(src))
+}
+
+// handleSchemaJSON is the JSON-sample counterpart of handleSchemaXML.
+func (s *Server) handleSchemaJSON(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodPost {
+        http.Error(w, "POST only", http.StatusMethodNotAllowed)
+        return
+    }
+    src, err := schemagen.FromJSONReaders(r.Body)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+    w.Write([]byte(src))
+}
+
+// typeView augments an Interface with the names of module types that
+// satisfy it, for the `/map/type/...` endpoint.
+type typeView struct {
+    Interface    *mapper.Interface
+    Implementers []string
+}
+
+func (s *Server) implementers(ifaceName string) []string {
+    var names []string
+    for _, pkg := range s.mod.Packages {
+        for _, t := range pkg.Types {
+            if s.mod.Implements(ifaceName, t.Name) {
+                names = append(names, pkg.ImportPath+"."+t.Name)
+            }
+        }
+    }
+    return names
+}